@@ -0,0 +1,178 @@
+// Package bom resolves the license of every module in a Go module
+// dependency tree and emits a bill of materials in several formats.
+package bom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	license "github.com/vmarkovtsev/go-license"
+)
+
+// defaultConcurrency bounds how many modules Resolve reads from disk at
+// once, mirroring the worker-pool pattern license.ScanTree uses for the
+// same reason: the module cache may be on a slow or networked filesystem.
+const defaultConcurrency = 8
+
+// Module is the subset of `go list -m -json` fields this package needs.
+type Module struct {
+	Path     string
+	Version  string
+	Dir      string
+	Main     bool
+	Indirect bool
+}
+
+// ParseModules reads the output of `go list -m -json all`, which is a
+// stream of concatenated JSON objects rather than a single array.
+func ParseModules(r io.Reader) ([]Module, error) {
+	dec := json.NewDecoder(r)
+
+	var modules []Module
+	for dec.More() {
+		var m Module
+		if err := dec.Decode(&m); err != nil {
+			return nil, fmt.Errorf("bom: decoding module list: %w", err)
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+// Entry is one resolved line of the bill of materials.
+type Entry struct {
+	Module Module
+
+	// License is the detected license type, e.g. "MIT". Empty if
+	// resolution failed.
+	License string
+
+	// LicenseFile is the path of the file the license was read from.
+	LicenseFile string
+
+	// Err records why resolution failed, nil on success.
+	Err error
+}
+
+// Unlicensed reports whether the module's license could not be determined.
+func (e Entry) Unlicensed() bool {
+	return e.Err != nil || e.License == ""
+}
+
+// Resolve looks up the on-disk license for every module that has a Dir
+// (the module cache path), skipping the main module itself. Modules are
+// resolved concurrently, bounded by defaultConcurrency, since each lookup
+// is a handful of blocking file system calls against the module cache.
+func Resolve(modules []Module) []Entry {
+	entries := make([]Entry, len(modules))
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, defaultConcurrency)
+	)
+	for i, m := range modules {
+		i, m := i, m
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			entries[i] = resolveOne(m)
+		}()
+	}
+	wg.Wait()
+
+	out := entries[:0]
+	for _, e := range entries {
+		if e.Module.Main {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// resolveOne resolves a single module's license.
+func resolveOne(m Module) Entry {
+	entry := Entry{Module: m}
+	if m.Main {
+		return entry
+	}
+	if m.Dir == "" {
+		entry.Err = errors.New("bom: module has no on-disk directory")
+		return entry
+	}
+
+	l, err := license.NewFromDir(m.Dir)
+	if err != nil {
+		entry.Err = err
+		return entry
+	}
+
+	entry.License = l.Type
+	entry.LicenseFile = l.File
+	return entry
+}
+
+// Policy constrains which license types a bill of materials may contain.
+// It embeds license.Policy so a bom check honors the same Allow/Deny/
+// IncompatiblePairs semantics as license.ScanTree, rather than a second,
+// weaker rule set.
+type Policy struct {
+	license.Policy
+
+	// FailOnUnlicensed rejects the bill of materials if any entry's
+	// license could not be determined.
+	FailOnUnlicensed bool
+}
+
+// PolicyError lists every entry that fails a Policy, alongside the
+// underlying license.Violation that explains why.
+type PolicyError struct {
+	Entries    []Entry
+	Violations []license.Violation
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("bom: %d module(s) violate the license policy", len(e.Entries))
+}
+
+// CheckPolicy validates entries against p, returning a *PolicyError listing
+// every offending entry, or nil if entries satisfies p.
+func CheckPolicy(entries []Entry, p Policy) error {
+	found := make(map[string]bool)
+	byType := make(map[string][]Entry)
+	var unlicensed []Entry
+
+	for _, e := range entries {
+		if e.Unlicensed() {
+			unlicensed = append(unlicensed, e)
+			continue
+		}
+		found[e.License] = true
+		byType[e.License] = append(byType[e.License], e)
+	}
+
+	violations := license.CheckLicenses(found, &p.Policy)
+
+	var bad []Entry
+	seen := make(map[string]bool)
+	for _, v := range violations {
+		if seen[v.Type] {
+			continue
+		}
+		seen[v.Type] = true
+		bad = append(bad, byType[v.Type]...)
+	}
+	if p.FailOnUnlicensed {
+		bad = append(bad, unlicensed...)
+	}
+
+	if len(bad) == 0 {
+		return nil
+	}
+	return &PolicyError{Entries: bad, Violations: violations}
+}
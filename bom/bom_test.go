@@ -0,0 +1,66 @@
+package bom
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	license "github.com/vmarkovtsev/go-license"
+)
+
+func TestParseModules(t *testing.T) {
+	input := `{"Path":"example.com/a","Version":"v1.0.0","Dir":"/tmp/a"}
+{"Path":"example.com/b","Version":"v2.0.0","Main":true}
+`
+	modules, err := ParseModules(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(modules) != 2 {
+		t.Fatalf("expected 2 modules, got %d", len(modules))
+	}
+	if modules[0].Path != "example.com/a" || modules[0].Dir != "/tmp/a" {
+		t.Fatalf("unexpected first module: %#v", modules[0])
+	}
+	if !modules[1].Main {
+		t.Fatalf("expected second module to be Main: %#v", modules[1])
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	entries := []Entry{
+		{Module: Module{Path: "example.com/a"}, License: "MIT"},
+		{Module: Module{Path: "example.com/b"}, License: "GPL-2.0"},
+		{Module: Module{Path: "example.com/c"}},
+	}
+
+	if err := CheckPolicy(entries, Policy{}); err != nil {
+		t.Fatalf("expected no violations with an empty policy, got: %v", err)
+	}
+
+	err := CheckPolicy(entries, Policy{Policy: license.Policy{Allow: []string{"MIT"}}, FailOnUnlicensed: true})
+	if err == nil {
+		t.Fatalf("expected a policy violation")
+	}
+	perr, ok := err.(*PolicyError)
+	if !ok {
+		t.Fatalf("expected *PolicyError, got %T", err)
+	}
+	if len(perr.Entries) != 2 {
+		t.Fatalf("expected 2 offending entries, got %d", len(perr.Entries))
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	entries := []Entry{
+		{Module: Module{Path: "example.com/a", Version: "v1.0.0"}, License: "MIT", LicenseFile: "/tmp/a/LICENSE"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, entries); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !strings.Contains(buf.String(), "example.com/a,v1.0.0,MIT,/tmp/a/LICENSE,") {
+		t.Fatalf("unexpected CSV output: %s", buf.String())
+	}
+}
@@ -0,0 +1,186 @@
+package bom
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonEntry is the plain JSON shape of one Entry.
+type jsonEntry struct {
+	Path        string `json:"path"`
+	Version     string `json:"version"`
+	License     string `json:"license,omitempty"`
+	LicenseFile string `json:"licenseFile,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func toJSONEntries(entries []Entry) []jsonEntry {
+	out := make([]jsonEntry, len(entries))
+	for i, e := range entries {
+		je := jsonEntry{
+			Path:        e.Module.Path,
+			Version:     e.Module.Version,
+			License:     e.License,
+			LicenseFile: e.LicenseFile,
+		}
+		if e.Err != nil {
+			je.Error = e.Err.Error()
+		}
+		out[i] = je
+	}
+	return out
+}
+
+// WriteJSON emits entries as a plain JSON array.
+func WriteJSON(w io.Writer, entries []Entry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toJSONEntries(entries))
+}
+
+// WriteCSV emits entries as "path,version,license,licenseFile,error" rows,
+// with a header row.
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"path", "version", "license", "licenseFile", "error"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		errStr := ""
+		if e.Err != nil {
+			errStr = e.Err.Error()
+		}
+		row := []string{e.Module.Path, e.Module.Version, e.License, e.LicenseFile, errStr}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SPDXDocOptions configures the SPDX 2.3 documents produced by
+// WriteSPDXTagValue and WriteSPDXJSON.
+type SPDXDocOptions struct {
+	// DocumentName is the SPDXID-bearing document's human-readable name.
+	DocumentName string
+
+	// NamespacePrefix is combined with a freshly generated UUID to form
+	// DocumentNamespace, e.g. "https://example.com/spdx".
+	NamespacePrefix string
+}
+
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+func pkgSPDXID(index int) string {
+	return fmt.Sprintf("SPDXRef-Package-%d", index)
+}
+
+func declaredLicense(e Entry) string {
+	if e.Unlicensed() {
+		return "NOASSERTION"
+	}
+	return e.License
+}
+
+// WriteSPDXTagValue emits entries as an SPDX 2.3 tag-value document.
+func WriteSPDXTagValue(w io.Writer, entries []Entry, opts SPDXDocOptions) error {
+	uuid, err := newUUID()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "SPDXVersion: SPDX-2.3")
+	fmt.Fprintln(w, "DataLicense: CC0-1.0")
+	fmt.Fprintln(w, "SPDXID: SPDXRef-DOCUMENT")
+	fmt.Fprintf(w, "DocumentName: %s\n", opts.DocumentName)
+	fmt.Fprintf(w, "DocumentNamespace: %s/%s\n", opts.NamespacePrefix, uuid)
+
+	for i, e := range entries {
+		id := pkgSPDXID(i)
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "PackageName: %s\n", e.Module.Path)
+		fmt.Fprintf(w, "SPDXID: %s\n", id)
+		fmt.Fprintf(w, "PackageVersion: %s\n", e.Module.Version)
+		fmt.Fprintf(w, "PackageLicenseDeclared: %s\n", declaredLicense(e))
+		fmt.Fprintf(w, "PackageLicenseConcluded: %s\n", declaredLicense(e))
+		fmt.Fprintln(w, "Relationship: SPDXRef-DOCUMENT DESCRIBES "+id)
+	}
+
+	return nil
+}
+
+// spdxJSONPackage and spdxJSONDoc mirror the subset of the SPDX 2.3 JSON
+// schema this package emits.
+type spdxJSONPackage struct {
+	Name             string `json:"name"`
+	SPDXID           string `json:"SPDXID"`
+	VersionInfo      string `json:"versionInfo,omitempty"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	LicenseConcluded string `json:"licenseConcluded"`
+}
+
+type spdxJSONRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+type spdxJSONDoc struct {
+	SPDXVersion       string                 `json:"spdxVersion"`
+	DataLicense       string                 `json:"dataLicense"`
+	SPDXID            string                 `json:"SPDXID"`
+	Name              string                 `json:"name"`
+	DocumentNamespace string                 `json:"documentNamespace"`
+	Packages          []spdxJSONPackage      `json:"packages"`
+	Relationships     []spdxJSONRelationship `json:"relationships"`
+}
+
+// WriteSPDXJSON emits entries as an SPDX 2.3 JSON document.
+func WriteSPDXJSON(w io.Writer, entries []Entry, opts SPDXDocOptions) error {
+	uuid, err := newUUID()
+	if err != nil {
+		return err
+	}
+
+	doc := spdxJSONDoc{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              opts.DocumentName,
+		DocumentNamespace: fmt.Sprintf("%s/%s", opts.NamespacePrefix, uuid),
+		Packages:          make([]spdxJSONPackage, len(entries)),
+		Relationships:     make([]spdxJSONRelationship, len(entries)),
+	}
+
+	for i, e := range entries {
+		id := pkgSPDXID(i)
+		doc.Packages[i] = spdxJSONPackage{
+			Name:             e.Module.Path,
+			SPDXID:           id,
+			VersionInfo:      e.Module.Version,
+			LicenseDeclared:  declaredLicense(e),
+			LicenseConcluded: declaredLicense(e),
+		}
+		doc.Relationships[i] = spdxJSONRelationship{
+			SPDXElementID:      "SPDXRef-DOCUMENT",
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: id,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,109 @@
+// Command go-license-bom generates a bill of materials for a Go module's
+// dependency tree by resolving the license of every module in the build
+// list.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	license "github.com/vmarkovtsev/go-license"
+	"github.com/vmarkovtsev/go-license/bom"
+)
+
+// outputFormats lists the -format values run accepts, checked up front so
+// an invalid value is rejected before anything is written to -out.
+var outputFormats = map[string]bool{
+	"json":      true,
+	"csv":       true,
+	"spdx-tv":   true,
+	"spdx-json": true,
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "go-license-bom:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("go-license-bom", flag.ExitOnError)
+	format := fs.String("format", "json", "output format: json, csv, spdx-tv, spdx-json")
+	output := fs.String("out", "", "output file (default: stdout)")
+	allow := fs.String("allow", "", "comma-separated allowlist of license types; empty allows any")
+	failUnlicensed := fs.Bool("fail-unlicensed", false, "exit non-zero if any dependency has no discoverable license")
+	namespace := fs.String("namespace", "https://example.com/spdx", "DocumentNamespace prefix for SPDX output")
+	docName := fs.String("name", "go-license-bom", "DocumentName for SPDX output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !outputFormats[*format] {
+		return fmt.Errorf("unknown -format %q", *format)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "list", "-m", "-json", "all")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("running go list: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	modules, err := bom.ParseModules(bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+
+	entries := bom.Resolve(modules)
+
+	var allowList []string
+	if *allow != "" {
+		allowList = strings.Split(*allow, ",")
+	}
+	policy := bom.Policy{Policy: license.Policy{Allow: allowList}, FailOnUnlicensed: *failUnlicensed}
+	if err := bom.CheckPolicy(entries, policy); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if perr, ok := err.(*bom.PolicyError); ok {
+			for _, e := range perr.Entries {
+				fmt.Fprintf(os.Stderr, "  %s@%s: %s\n", e.Module.Path, e.Module.Version, describe(e))
+			}
+		}
+		return fmt.Errorf("license policy check failed")
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	opts := bom.SPDXDocOptions{DocumentName: *docName, NamespacePrefix: *namespace}
+	switch *format {
+	case "json":
+		return bom.WriteJSON(w, entries)
+	case "csv":
+		return bom.WriteCSV(w, entries)
+	case "spdx-tv":
+		return bom.WriteSPDXTagValue(w, entries, opts)
+	case "spdx-json":
+		return bom.WriteSPDXJSON(w, entries, opts)
+	default:
+		return fmt.Errorf("unknown -format %q", *format)
+	}
+}
+
+func describe(e bom.Entry) string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return "license not in allowlist: " + e.License
+}
@@ -0,0 +1,80 @@
+package license
+
+// Corpus supplies the set of known license texts and the conventional file
+// names/extensions used to locate license files on disk. The built-in
+// fixtures satisfy this interface; SetCorpus lets callers swap in a larger
+// or more current set (see LoadSPDXCorpus) without recompiling.
+type Corpus interface {
+	// Licenses returns a map of license type to full license text.
+	Licenses() map[string]string
+
+	// FileNames returns the conventional base names under which license
+	// files are stored, e.g. "LICENSE".
+	FileNames() []string
+
+	// Extensions returns the conventional extensions (including "") that
+	// license file names are found with.
+	Extensions() []string
+}
+
+// builtinCorpus exposes the package's compiled-in license table and file
+// name conventions as a Corpus.
+type builtinCorpus struct{}
+
+func (builtinCorpus) Licenses() map[string]string {
+	out := make(map[string]string, len(licenseTable))
+	for k, v := range licenseTable {
+		out[k] = v
+	}
+	return out
+}
+
+func (builtinCorpus) FileNames() []string {
+	out := make([]string, len(fileNames))
+	copy(out, fileNames)
+	return out
+}
+
+func (builtinCorpus) Extensions() []string {
+	out := make([]string, len(fileExtensions))
+	copy(out, fileExtensions)
+	return out
+}
+
+// DefaultCorpus is the built-in Corpus used until SetCorpus is called.
+var DefaultCorpus Corpus = builtinCorpus{}
+
+// SetCorpus replaces the package's license table and file-name conventions
+// with those supplied by c, rebuilding the search tables and invalidating
+// the default fuzzy Matcher. It is not safe to call concurrently with
+// lookups such as GuessType, NewFromDir, or SearchDir.
+func SetCorpus(c Corpus) {
+	licenseTable = c.Licenses()
+	KnownLicenses = func() []string {
+		names := make([]string, 0, len(licenseTable))
+		for name := range licenseTable {
+			names = append(names, name)
+		}
+		return names
+	}()
+
+	fileNames = c.FileNames()
+	fileExtensions = c.Extensions()
+	rebuildFileTable()
+
+	resetDefaultMatcher()
+}
+
+// NewWithCorpus guesses the license type of text against c instead of the
+// package's current default corpus, without mutating any global state.
+func NewWithCorpus(text string, c Corpus) (*License, error) {
+	l := &License{Text: text}
+
+	matches := NewMatcher(c.Licenses()).Match(text, 1)
+	if len(matches) == 0 || matches[0].Score < DefaultMatchThreshold {
+		return nil, ErrUnrecognizedLicense
+	}
+
+	l.Type = matches[0].Type
+	return l, nil
+}
@@ -0,0 +1,49 @@
+package license
+
+import "strings"
+
+// fileNames lists the conventional base names under which projects store
+// their license text.
+var fileNames = []string{
+	"LICENSE",
+	"LICENCE",
+	"COPYING",
+	"COPYRIGHT",
+}
+
+// fileExtensions lists the extensions (including none) that conventional
+// license file names are found with.
+var fileExtensions = []string{
+	"",
+	".md",
+	".txt",
+	".rst",
+}
+
+// fileTable maps the lower-cased form of every recognized license file name
+// to its canonical (on-disk) spelling.
+var fileTable map[string]string
+
+// DefaultLicenseFiles is the set of conventional license file names,
+// generated from fileNames and fileExtensions, in a fixed canonical order.
+var DefaultLicenseFiles []string
+
+func init() {
+	rebuildFileTable()
+}
+
+// rebuildFileTable regenerates fileTable and DefaultLicenseFiles from the
+// current fileNames and fileExtensions. It is called at package init and
+// again by SetCorpus whenever the file-name conventions change.
+func rebuildFileTable() {
+	fileTable = make(map[string]string, len(fileNames)*len(fileExtensions))
+	DefaultLicenseFiles = make([]string, 0, len(fileNames)*len(fileExtensions))
+
+	for _, name := range fileNames {
+		for _, ext := range fileExtensions {
+			full := name + ext
+			fileTable[strings.ToLower(full)] = full
+			DefaultLicenseFiles = append(DefaultLicenseFiles, full)
+		}
+	}
+}
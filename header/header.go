@@ -0,0 +1,275 @@
+// Package header inserts and verifies copyright/SPDX license headers in
+// source files, in the style of addlicense.
+package header
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	license "github.com/vmarkovtsev/go-license"
+)
+
+// CommentStyle describes how to wrap a header for a given file type. Top
+// and Bottom delimit a block comment and are empty for line-comment
+// languages; Line prefixes every header line in both styles.
+type CommentStyle struct {
+	Top    string
+	Line   string
+	Bottom string
+}
+
+// commentStyles maps a lower-cased file extension (including the leading
+// dot) to the CommentStyle used to wrap headers in that kind of file.
+var commentStyles = map[string]CommentStyle{
+	".go":   {Line: "// "},
+	".c":    {Top: "/*", Line: " * ", Bottom: " */"},
+	".h":    {Top: "/*", Line: " * ", Bottom: " */"},
+	".cc":   {Top: "/*", Line: " * ", Bottom: " */"},
+	".cpp":  {Top: "/*", Line: " * ", Bottom: " */"},
+	".hpp":  {Top: "/*", Line: " * ", Bottom: " */"},
+	".java": {Top: "/*", Line: " * ", Bottom: " */"},
+	".js":   {Top: "/*", Line: " * ", Bottom: " */"},
+	".ts":   {Top: "/*", Line: " * ", Bottom: " */"},
+	".css":  {Top: "/*", Line: " * ", Bottom: " */"},
+	".py":   {Line: "# "},
+	".sh":   {Line: "# "},
+	".bash": {Line: "# "},
+	".rb":   {Line: "# "},
+	".yml":  {Line: "# "},
+	".yaml": {Line: "# "},
+	".toml": {Line: "# "},
+	".sql":  {Line: "-- "},
+	".lisp": {Line: ";; "},
+	".el":   {Line: ";; "},
+	".html": {Top: "<!--", Line: "  ", Bottom: "-->"},
+	".xml":  {Top: "<!--", Line: "  ", Bottom: "-->"},
+}
+
+// LookupCommentStyle returns the CommentStyle registered for the given file
+// extension (as returned by filepath.Ext), and whether one was found.
+func LookupCommentStyle(ext string) (CommentStyle, bool) {
+	cs, ok := commentStyles[strings.ToLower(ext)]
+	return cs, ok
+}
+
+// ApplyOptions configures Apply and Check.
+type ApplyOptions struct {
+	// License is the license whose SPDX identifier and/or text is used to
+	// build the header. It must have a non-empty Type.
+	License *license.License
+
+	// Holder is the copyright holder, e.g. "Jane Doe".
+	Holder string
+
+	// Year is the copyright year or range, e.g. "2026" or "2020-2026".
+	Year string
+
+	// SPDXOnly emits a single-line "SPDX-License-Identifier:" tag instead
+	// of a full copyright + license header.
+	SPDXOnly bool
+
+	// IgnorePatterns are filepath.Match patterns (no "**" support), matched
+	// against both the full path and its base name, that are skipped
+	// entirely.
+	IgnorePatterns []string
+}
+
+// markerPrefix is the substring Check/Apply look for to decide whether a
+// file already carries a header, regardless of comment style.
+const markerPrefix = "SPDX-License-Identifier:"
+
+// buildHeaderLines returns the header's content lines, unwrapped in any
+// comment syntax.
+func buildHeaderLines(opts ApplyOptions) []string {
+	spdxLine := markerPrefix + " " + spdxID(opts.License)
+
+	if opts.SPDXOnly {
+		return []string{spdxLine}
+	}
+
+	copyrightLine := "Copyright (c)"
+	if opts.Year != "" {
+		copyrightLine += " " + opts.Year
+	}
+	if opts.Holder != "" {
+		copyrightLine += " " + opts.Holder
+	}
+
+	return []string{copyrightLine, spdxLine}
+}
+
+func spdxID(l *license.License) string {
+	if l == nil {
+		return ""
+	}
+	if l.SPDXID != "" {
+		return l.SPDXID
+	}
+	return l.Type
+}
+
+// render wraps lines in cs's comment syntax, ready to prepend to a file.
+func render(cs CommentStyle, lines []string) string {
+	var b strings.Builder
+	if cs.Top != "" {
+		b.WriteString(cs.Top)
+		b.WriteString("\n")
+	}
+	for _, line := range lines {
+		b.WriteString(cs.Line)
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if cs.Bottom != "" {
+		b.WriteString(cs.Bottom)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// preservedFirstLines returns the number of lines at the start of content
+// that must stay ahead of any inserted header: a "#!" shebang, and for Go
+// files the "//go:build" or "// +build" constraint line(s) that may follow
+// it (with the blank line separating them from the package clause). Headers
+// inserted ahead of these corrupt a shebang script or silently disable a
+// build constraint, so Apply (through insertHeader) inserts after them
+// instead of at byte 0.
+func preservedFirstLines(lines []string) int {
+	n := 0
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "#!") {
+		n = 1
+	}
+	for n < len(lines) && (strings.HasPrefix(lines[n], "//go:build") || strings.HasPrefix(lines[n], "// +build")) {
+		n++
+	}
+	if n > 0 && n < len(lines) && strings.TrimSpace(lines[n]) == "" {
+		n++
+	}
+	return n
+}
+
+// insertHeader splices header into content immediately after any leading
+// shebang/build-constraint lines, instead of always at byte 0.
+func insertHeader(content, header string) string {
+	lines := strings.SplitAfter(content, "\n")
+	n := preservedFirstLines(lines)
+	if n == 0 {
+		return header + content
+	}
+	prefix := strings.Join(lines[:n], "")
+	return prefix + header + strings.Join(lines[n:], "")
+}
+
+// hasHeader reports whether content already carries a header, identified by
+// the presence of markerPrefix within the first few lines.
+func hasHeader(content string) bool {
+	lines := strings.SplitN(content, "\n", 21)
+	if len(lines) > 20 {
+		lines = lines[:20]
+	}
+	for _, line := range lines {
+		if strings.Contains(line, markerPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectPaths filters paths against opts.IgnorePatterns.
+func selectPaths(paths []string, opts ApplyOptions) []string {
+	kept := make([]string, 0, len(paths))
+	for _, p := range paths {
+		ignored := false
+		for _, pattern := range opts.IgnorePatterns {
+			if ok, _ := filepath.Match(pattern, p); ok {
+				ignored = true
+				break
+			}
+			if ok, _ := filepath.Match(pattern, filepath.Base(p)); ok {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// Apply inserts a header into every path in paths that doesn't already have
+// one, using the comment style registered for its extension. It returns the
+// paths it actually modified. Re-running Apply over the same paths is a
+// no-op: it is idempotent.
+func Apply(paths []string, opts ApplyOptions) ([]string, error) {
+	var changed []string
+
+	for _, path := range selectPaths(paths, opts) {
+		cs, ok := LookupCommentStyle(filepath.Ext(path))
+		if !ok {
+			continue
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return changed, err
+		}
+		content := string(raw)
+
+		if hasHeader(content) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return changed, err
+		}
+
+		header := render(cs, buildHeaderLines(opts))
+		out := insertHeader(content, header)
+		if err := os.WriteFile(path, []byte(out), info.Mode()); err != nil {
+			return changed, err
+		}
+		changed = append(changed, path)
+	}
+
+	return changed, nil
+}
+
+// Check reports which of paths lack a valid header, without modifying any
+// file. It is suitable for use in CI: a non-empty result should fail the
+// build.
+func Check(paths []string, opts ApplyOptions) ([]string, error) {
+	var missing []string
+
+	for _, path := range selectPaths(paths, opts) {
+		if _, ok := LookupCommentStyle(filepath.Ext(path)); !ok {
+			continue
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return missing, err
+		}
+
+		if !hasHeader(string(raw)) {
+			missing = append(missing, path)
+		}
+	}
+
+	return missing, nil
+}
+
+// YearRange formats a copyright year range, collapsing a single year to
+// just that year, e.g. YearRange(2020, 2020) == "2020" and
+// YearRange(2020, 2026) == "2020-2026".
+func YearRange(from, to int) string {
+	if from == to {
+		return strconv.Itoa(from)
+	}
+	return fmt.Sprintf("%d-%d", from, to)
+}
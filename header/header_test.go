@@ -0,0 +1,145 @@
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	license "github.com/vmarkovtsev/go-license"
+)
+
+func TestApplyAndCheck(t *testing.T) {
+	dir, err := os.MkdirTemp("", "go-license-header")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte("package main\n"), 0600); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	opts := ApplyOptions{
+		License: license.New(license.LicenseMIT, ""),
+		Holder:  "Jane Doe",
+		Year:    "2026",
+	}
+
+	missing, err := Check([]string{path}, opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(missing) != 1 {
+		t.Fatalf("expected 1 missing header, got %d", len(missing))
+	}
+
+	changed, err := Apply([]string{path}, opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("expected 1 changed file, got %d", len(changed))
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !strings.Contains(string(raw), "SPDX-License-Identifier: MIT") {
+		t.Fatalf("header not inserted: %s", raw)
+	}
+	if !strings.HasSuffix(string(raw), "package main\n") {
+		t.Fatalf("original content not preserved: %s", raw)
+	}
+
+	// Re-applying must be a no-op.
+	changed, err = Apply([]string{path}, opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no changes on second apply, got %v", changed)
+	}
+
+	missing, err = Check([]string{path}, opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Fatalf("expected no missing headers, got %v", missing)
+	}
+}
+
+func TestApplyPreservesShebang(t *testing.T) {
+	dir, err := os.MkdirTemp("", "go-license-header")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "run.sh")
+	const script = "#!/bin/sh\necho hello\n"
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	opts := ApplyOptions{
+		License: license.New(license.LicenseMIT, ""),
+		Holder:  "Jane Doe",
+		Year:    "2026",
+	}
+
+	if _, err := Apply([]string{path}, opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	lines := strings.SplitN(string(raw), "\n", 2)
+	if lines[0] != "#!/bin/sh" {
+		t.Fatalf("shebang must stay on the first line, got: %s", raw)
+	}
+	if !strings.HasSuffix(string(raw), "echo hello\n") {
+		t.Fatalf("original script body not preserved: %s", raw)
+	}
+}
+
+func TestApplyPreservesGoBuildConstraint(t *testing.T) {
+	dir, err := os.MkdirTemp("", "go-license-header")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tags.go")
+	const src = "//go:build linux\n\npackage main\n"
+	if err := os.WriteFile(path, []byte(src), 0600); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	opts := ApplyOptions{
+		License: license.New(license.LicenseMIT, ""),
+		Holder:  "Jane Doe",
+		Year:    "2026",
+	}
+
+	if _, err := Apply([]string{path}, opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	lines := strings.SplitN(string(raw), "\n", 2)
+	if lines[0] != "//go:build linux" {
+		t.Fatalf("build constraint must stay on the first line, got: %s", raw)
+	}
+	if !strings.HasSuffix(string(raw), "package main\n") {
+		t.Fatalf("original content not preserved: %s", raw)
+	}
+}
@@ -0,0 +1,151 @@
+// Package license identifies the license governing a piece of source code
+// or a project directory, and provides helpers for locating and loading
+// license files from disk.
+package license
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Errors returned while locating or identifying a license.
+var (
+	ErrNoLicenseFile       = errors.New("license: no license file found")
+	ErrMultipleLicenses    = errors.New("license: multiple license files found")
+	ErrUnrecognizedLicense = errors.New("license: unrecognized license text")
+)
+
+// License represents a software license, optionally backed by a file on
+// disk.
+type License struct {
+	// Type is the canonical name of the license, e.g. "MIT". It is either
+	// supplied directly or guessed from Text via GuessType.
+	Type string
+
+	// Text is the raw license text.
+	Text string
+
+	// File is the path the license was loaded from, if any.
+	File string
+
+	// SPDXID is the SPDX short-form license identifier corresponding to
+	// Type, e.g. "Apache-2.0", if one is known.
+	SPDXID string
+}
+
+// New returns a new License with the given type and text.
+func New(licenseType, licenseText string) *License {
+	return &License{
+		Type: licenseType,
+		Text: licenseText,
+	}
+}
+
+// NewFromFile reads the file at path and attempts to guess the type of
+// license it contains.
+func NewFromFile(path string) (*License, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &License{
+		File: path,
+		Text: string(raw),
+	}
+	if err := l.GuessType(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// NewFromDir searches dir for a single recognizable license file and loads
+// it. It returns ErrNoLicenseFile if none is found, or ErrMultipleLicenses
+// if more than one candidate file is present.
+func NewFromDir(dir string) (*License, error) {
+	matches, err := SearchDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, ErrNoLicenseFile
+	case 1:
+		return NewFromFile(filepath.Join(dir, matches[0]))
+	default:
+		return nil, ErrMultipleLicenses
+	}
+}
+
+// SearchDir returns the conventional license file names found directly
+// inside dir, in the canonical order they appear in DefaultLicenseFiles.
+// Matching against the file system is case-insensitive.
+func SearchDir(dir string) ([]string, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	for _, fi := range fis {
+		if fi.IsDir() {
+			continue
+		}
+		if canonical, ok := fileTable[strings.ToLower(fi.Name())]; ok {
+			found[canonical] = true
+		}
+	}
+
+	result := make([]string, 0, len(found))
+	for _, name := range DefaultLicenseFiles {
+		if found[name] {
+			result = append(result, name)
+		}
+	}
+
+	return result, nil
+}
+
+// Recognized reports whether l.Type matches one of the known license types.
+func (l *License) Recognized() bool {
+	_, ok := licenseTable[l.Type]
+	return ok
+}
+
+// apacheAbbreviated matches the common practice of referencing the Apache
+// 2.0 license by URL instead of inlining its full text.
+var apacheAbbreviated = regexp.MustCompile(`apache\.org/licenses/license-2\.0`)
+
+// GuessType attempts to identify l.Text against the table of known license
+// texts, setting l.Type on success. It is a thin wrapper around
+// GuessTypeWithConfidence that accepts the top match if it clears the
+// package's default Matcher's threshold (DefaultMatchThreshold unless
+// changed via SetMatchThreshold), and returns ErrUnrecognizedLicense
+// otherwise.
+func (l *License) GuessType() error {
+	matches, err := l.GuessTypeWithConfidence()
+	if err != nil {
+		return err
+	}
+
+	if matches[0].Score < getDefaultMatcher().threshold {
+		return ErrUnrecognizedLicense
+	}
+
+	l.Type = matches[0].Type
+	return nil
+}
+
+// normalize lower-cases s, replaces commas with spaces and collapses runs
+// of whitespace so that trivially reformatted license texts still compare
+// equal. Other punctuation is kept.
+func normalize(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Replace(s, ",", " ", -1)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,230 @@
+package license
+
+import (
+	"errors"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Match is a candidate license type returned by GuessTypeWithConfidence,
+// ranked by similarity to the input text.
+type Match struct {
+	Type  string
+	Score float64
+}
+
+// DefaultMatchThreshold is the minimum Jaccard similarity score GuessType
+// requires before accepting a Matcher's top candidate.
+const DefaultMatchThreshold = 0.75
+
+const (
+	shingleSize = 5
+	numHashes   = 128
+	defaultTopK = 3
+)
+
+// Matcher identifies the closest known license type for a block of text by
+// comparing MinHash sketches of their token shingles, which tolerates
+// reformatting, wrapping, and small edits that defeat exact comparison.
+type Matcher struct {
+	threshold float64
+	topK      int
+	sketches  map[string][]uint64
+}
+
+// NewMatcher builds a Matcher over the given license corpus (type -> full
+// text), precomputing a MinHash sketch for each entry.
+func NewMatcher(corpus map[string]string) *Matcher {
+	m := &Matcher{
+		threshold: DefaultMatchThreshold,
+		topK:      defaultTopK,
+		sketches:  make(map[string][]uint64, len(corpus)),
+	}
+	for ltype, text := range corpus {
+		m.sketches[ltype] = sketch(text)
+	}
+	return m
+}
+
+// defaultMatcher is lazily built over the built-in license table the first
+// time fuzzy matching is needed. defaultMatcherOnce guards that lazy build,
+// since ScanTree and bom.Resolve both call into GuessType from many
+// goroutines concurrently.
+var (
+	defaultMatcherOnce sync.Once
+	defaultMatcher     *Matcher
+)
+
+func getDefaultMatcher() *Matcher {
+	defaultMatcherOnce.Do(func() {
+		defaultMatcher = NewMatcher(licenseTable)
+	})
+	return defaultMatcher
+}
+
+// resetDefaultMatcher discards the cached default Matcher so it is rebuilt,
+// over the current licenseTable, the next time it's needed. Like
+// SetCorpus, it is not safe to call concurrently with lookups such as
+// GuessType.
+func resetDefaultMatcher() {
+	defaultMatcherOnce = sync.Once{}
+	defaultMatcher = nil
+}
+
+// SetMatchThreshold adjusts the minimum score GuessType requires from the
+// package's default Matcher before accepting its top candidate. It is not
+// safe to call concurrently with lookups such as GuessType.
+func SetMatchThreshold(threshold float64) {
+	getDefaultMatcher().threshold = threshold
+}
+
+// WithThreshold returns a copy of the Matcher with its threshold set to the
+// given minimum score. Match does not consult the threshold, and GuessType
+// only consults the package's default Matcher (see SetMatchThreshold).
+func (m *Matcher) WithThreshold(threshold float64) *Matcher {
+	clone := *m
+	clone.threshold = threshold
+	return &clone
+}
+
+// Match returns up to topK candidate license types for text, ranked by
+// descending similarity score.
+func (m *Matcher) Match(text string, topK int) []Match {
+	if topK <= 0 {
+		topK = m.topK
+	}
+	target := sketch(text)
+
+	matches := make([]Match, 0, len(m.sketches))
+	for ltype, s := range m.sketches {
+		matches = append(matches, Match{Type: ltype, Score: jaccard(target, s)})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Type < matches[j].Type
+	})
+
+	if topK < len(matches) {
+		matches = matches[:topK]
+	}
+	return matches
+}
+
+var (
+	reCopyrightLine = regexp.MustCompile(`(?i)^\s*copyright\s*(\([cC]\)|©)?\s*[\d,\s-]*.*$`)
+	reURL           = regexp.MustCompile(`https?://\S+`)
+	reListBullet    = regexp.MustCompile(`(?m)^\s*[-*•]\s+`)
+	reYear          = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+)
+
+// normalizeForMatch extends normalize with the additional cleanup needed
+// before fuzzy comparison: copyright lines, URLs, list bullets, and years
+// are stripped since they vary between otherwise-identical license texts.
+func normalizeForMatch(s string) string {
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if reCopyrightLine.MatchString(line) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	s = strings.Join(kept, "\n")
+	s = reURL.ReplaceAllString(s, " ")
+	s = reListBullet.ReplaceAllString(s, " ")
+	s = reYear.ReplaceAllString(s, " ")
+	return normalize(s)
+}
+
+// shingles splits normalized text into words and returns the set of
+// overlapping word n-grams of length shingleSize.
+func shingles(text string) []string {
+	words := strings.Fields(normalizeForMatch(text))
+	if len(words) < shingleSize {
+		if len(words) == 0 {
+			return nil
+		}
+		return []string{strings.Join(words, " ")}
+	}
+	out := make([]string, 0, len(words)-shingleSize+1)
+	for i := 0; i+shingleSize <= len(words); i++ {
+		out = append(out, strings.Join(words[i:i+shingleSize], " "))
+	}
+	return out
+}
+
+// sketch computes a MinHash sketch of numHashes values over the shingles of
+// text, using independent FNV-1a hashes salted per position.
+func sketch(text string) []uint64 {
+	grams := shingles(text)
+	sk := make([]uint64, numHashes)
+	for i := range sk {
+		sk[i] = ^uint64(0)
+	}
+	if len(grams) == 0 {
+		return sk
+	}
+
+	for _, g := range grams {
+		for i := 0; i < numHashes; i++ {
+			h := hashWithSeed(g, uint64(i))
+			if h < sk[i] {
+				sk[i] = h
+			}
+		}
+	}
+	return sk
+}
+
+func hashWithSeed(s string, seed uint64) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for i := 0; i < 8; i++ {
+		buf[i] = byte(seed >> (8 * i))
+	}
+	h.Write(buf[:])
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// jaccard estimates the Jaccard similarity between two MinHash sketches as
+// the fraction of hash slots that agree.
+func jaccard(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	agree := 0
+	for i := range a {
+		if a[i] == b[i] {
+			agree++
+		}
+	}
+	return float64(agree) / float64(len(a))
+}
+
+// ErrNoConfidentMatch describes a best fuzzy match that falls below the
+// matcher's threshold. GuessType and NewWithCorpus report that case as
+// ErrUnrecognizedLicense; no function in this package returns
+// ErrNoConfidentMatch.
+var ErrNoConfidentMatch = errors.New("license: no license matched with sufficient confidence")
+
+// GuessTypeWithConfidence returns the ranked candidate license types for
+// l.Text, most similar first, using the package's default Matcher.
+func (l *License) GuessTypeWithConfidence() ([]Match, error) {
+	cleaned := normalize(l.Text)
+	if apacheAbbreviated.MatchString(cleaned) {
+		return []Match{{Type: LicenseApache20, Score: 1}}, nil
+	}
+
+	matches := getDefaultMatcher().Match(l.Text, defaultTopK)
+	if len(matches) == 0 {
+		return nil, ErrUnrecognizedLicense
+	}
+	return matches, nil
+}
@@ -0,0 +1,393 @@
+package license
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// spdxIDTable maps a curated set of SPDX short-form license identifiers to
+// the internal license type they correspond to.
+var spdxIDTable = map[string]string{
+	"MIT":          LicenseMIT,
+	"Apache-2.0":   LicenseApache20,
+	"BSD-2-Clause": LicenseBSD2Clause,
+	"BSD-3-Clause": LicenseBSD3Clause,
+	"ISC":          LicenseISC,
+	"MPL-2.0":      LicenseMPL20,
+	"Unlicense":    LicenseUnlicense,
+}
+
+// LookupSPDX returns the License corresponding to the given SPDX short-form
+// identifier, if known.
+func LookupSPDX(id string) (*License, bool) {
+	ltype, ok := spdxIDTable[id]
+	if !ok {
+		return nil, false
+	}
+	l := New(ltype, licenseTable[ltype])
+	l.SPDXID = id
+	return l, true
+}
+
+// ExprKind identifies the shape of a node in a parsed SPDX license
+// expression.
+type ExprKind int
+
+// Node kinds produced by ParseExpression.
+const (
+	LicenseRef ExprKind = iota
+	And
+	Or
+	With
+)
+
+// Expr is a node in an SPDX license expression AST, e.g. the parsed form of
+// "Apache-2.0 OR (MIT AND BSD-3-Clause)".
+type Expr struct {
+	Kind ExprKind
+
+	// License is set for LicenseRef and With nodes.
+	License string
+
+	// Exception is set for With nodes, e.g. "Classpath-exception-2.0".
+	Exception string
+
+	// Left and Right are set for And and Or nodes.
+	Left  *Expr
+	Right *Expr
+}
+
+// String renders the expression back into SPDX expression syntax.
+func (e *Expr) String() string {
+	if e == nil {
+		return ""
+	}
+	switch e.Kind {
+	case LicenseRef:
+		return e.License
+	case With:
+		return e.License + " WITH " + e.Exception
+	case And:
+		return "(" + e.Left.String() + " AND " + e.Right.String() + ")"
+	case Or:
+		return "(" + e.Left.String() + " OR " + e.Right.String() + ")"
+	}
+	return ""
+}
+
+// Licenses returns the set of distinct license identifiers referenced
+// anywhere in the expression.
+func (e *Expr) Licenses() map[string]bool {
+	found := make(map[string]bool)
+	var walk func(*Expr)
+	walk = func(n *Expr) {
+		if n == nil {
+			return
+		}
+		switch n.Kind {
+		case LicenseRef, With:
+			found[n.License] = true
+		case And, Or:
+			walk(n.Left)
+			walk(n.Right)
+		}
+	}
+	walk(e)
+	return found
+}
+
+// ExprSyntaxError reports a malformed SPDX expression.
+type ExprSyntaxError struct {
+	Expression string
+
+	// Pos is the index, within the tokens tokenizeExpr splits Expression
+	// into, at which parsing failed, not a byte offset into Expression.
+	Pos int
+
+	Msg string
+}
+
+func (e *ExprSyntaxError) Error() string {
+	return fmt.Sprintf("license: invalid SPDX expression %q at token %d: %s", e.Expression, e.Pos, e.Msg)
+}
+
+// exprParser is a small recursive-descent parser for SPDX license
+// expressions: expr := term (("AND"|"OR") term)* ; term := licenseRef
+// ["WITH" exception] | "(" expr ")".
+type exprParser struct {
+	expr string
+	toks []string
+	pos  int
+}
+
+func tokenizeExpr(s string) []string {
+	re := regexp.MustCompile(`\(|\)|[^\s()]+`)
+	return re.FindAllString(s, -1)
+}
+
+// ParseExpression parses an SPDX license expression such as
+// "Apache-2.0 OR GPL-2.0-or-later" or "(MIT AND BSD-3-Clause)" into an Expr
+// tree.
+func ParseExpression(s string) (*Expr, error) {
+	p := &exprParser{expr: s, toks: tokenizeExpr(s)}
+	if len(p.toks) == 0 {
+		return nil, &ExprSyntaxError{Expression: s, Pos: 0, Msg: "empty expression"}
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, &ExprSyntaxError{Expression: s, Pos: p.pos, Msg: "unexpected trailing tokens"}
+	}
+	return e, nil
+}
+
+func (p *exprParser) peek() string {
+	if p.pos >= len(p.toks) {
+		return ""
+	}
+	return p.toks[p.pos]
+}
+
+func (p *exprParser) parseOr() (*Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Expr{Kind: Or, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (*Expr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "AND") {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &Expr{Kind: And, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseTerm() (*Expr, error) {
+	tok := p.peek()
+	if tok == "" {
+		return nil, &ExprSyntaxError{Expression: p.expr, Pos: p.pos, Msg: "expected license identifier"}
+	}
+	if tok == "(" {
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, &ExprSyntaxError{Expression: p.expr, Pos: p.pos, Msg: "expected closing parenthesis"}
+		}
+		p.pos++
+		return e, nil
+	}
+	if tok == ")" {
+		return nil, &ExprSyntaxError{Expression: p.expr, Pos: p.pos, Msg: "unexpected closing parenthesis"}
+	}
+
+	p.pos++
+	ref := &Expr{Kind: LicenseRef, License: tok}
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.pos++
+		exc := p.peek()
+		if exc == "" {
+			return nil, &ExprSyntaxError{Expression: p.expr, Pos: p.pos, Msg: "expected exception identifier after WITH"}
+		}
+		p.pos++
+		ref.Kind = With
+		ref.Exception = exc
+	}
+	return ref, nil
+}
+
+// ScanOptions configures ScanSPDX.
+type ScanOptions struct {
+	// MaxLines bounds how many lines of each file are scanned for an
+	// SPDX-License-Identifier tag. Zero selects a sane default.
+	MaxLines int
+
+	// IgnorePatterns are filepath.Match-style globs, matched against paths
+	// relative to the scan root, that are skipped entirely.
+	IgnorePatterns []string
+
+	// Concurrency bounds the number of files scanned in parallel. Zero
+	// selects a sane default.
+	Concurrency int
+
+	// Policy is consulted by ScanTree to populate Report.Violations. It is
+	// unused by ScanSPDX.
+	Policy *Policy
+}
+
+const defaultSPDXMaxLines = 20
+const defaultSPDXConcurrency = 8
+
+// spdxTagRegexp matches an SPDX-License-Identifier tag embedded in any of
+// the common single- and multi-line comment styles ("//", "#", "/* */",
+// "--", ";", "<!-- -->").
+var spdxTagRegexp = regexp.MustCompile(`SPDX-License-Identifier:\s*(.+)`)
+
+// cleanSPDXTail strips trailing comment-close markers and whitespace left
+// over after extracting the expression text from its comment.
+func cleanSPDXTail(s string) string {
+	s = strings.TrimRight(s, " \t\r\n")
+	for _, suffix := range []string{"*/", "-->"} {
+		if strings.HasSuffix(s, suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, suffix))
+		}
+	}
+	return s
+}
+
+// ScanSPDX walks the directory tree rooted at root looking for
+// SPDX-License-Identifier tags. It returns the parsed expression found in
+// each matching file, keyed by path relative to root, and the aggregated
+// set of license identifiers referenced anywhere in the tree.
+func ScanSPDX(root string, opts *ScanOptions) (map[string]*Expr, map[string]bool, error) {
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+	maxLines := opts.MaxLines
+	if maxLines <= 0 {
+		maxLines = defaultSPDXMaxLines
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultSPDXConcurrency
+	}
+
+	var paths []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if matchesAny(opts.IgnorePatterns, rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+		results  = make(map[string]*Expr)
+		aggr     = make(map[string]bool)
+		firstErr error
+	)
+
+	for _, p := range paths {
+		p := p
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			expr, err := scanFileForSPDX(p, maxLines)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			if expr == nil {
+				return
+			}
+			rel, relErr := filepath.Rel(root, p)
+			if relErr != nil {
+				rel = p
+			}
+			results[rel] = expr
+			for id := range expr.Licenses() {
+				aggr[id] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+	return results, aggr, nil
+}
+
+// scanFileForSPDX reads up to maxLines lines of path and returns the parsed
+// SPDX expression, if any tag is present.
+func scanFileForSPDX(path string, maxLines int) (*Expr, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < maxLines && scanner.Scan(); i++ {
+		line := scanner.Text()
+		m := spdxTagRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		expr := cleanSPDXTail(m[1])
+		return ParseExpression(expr)
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// matchesAny reports whether rel matches any of the given filepath.Match
+// globs, checked both against the full relative path and its base name.
+func matchesAny(globs []string, rel string) bool {
+	for _, g := range globs {
+		if ok, _ := filepath.Match(g, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(g, filepath.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package license
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// spdxLicenseListData mirrors the subset of the official SPDX
+// license-list-data licenses.json schema that this package needs.
+type spdxLicenseListData struct {
+	LicenseListVersion string             `json:"licenseListVersion"`
+	Licenses           []spdxLicenseEntry `json:"licenses"`
+}
+
+type spdxLicenseEntry struct {
+	LicenseID             string `json:"licenseId"`
+	IsDeprecatedLicenseID bool   `json:"isDeprecatedLicenseId"`
+}
+
+// SPDXCorpus is a Corpus backed by an on-disk copy of the official SPDX
+// license-list-data repository (licenses.json plus one template .txt per
+// license ID), letting callers recognize hundreds of licenses without a
+// recompile.
+type SPDXCorpus struct {
+	version  string
+	licenses map[string]string
+}
+
+// Version returns the licenseListVersion recorded in the loaded
+// licenses.json.
+func (c *SPDXCorpus) Version() string { return c.version }
+
+func (c *SPDXCorpus) Licenses() map[string]string {
+	out := make(map[string]string, len(c.licenses))
+	for k, v := range c.licenses {
+		out[k] = v
+	}
+	return out
+}
+
+func (c *SPDXCorpus) FileNames() []string {
+	out := make([]string, len(fileNames))
+	copy(out, fileNames)
+	return out
+}
+
+func (c *SPDXCorpus) Extensions() []string {
+	out := make([]string, len(fileExtensions))
+	copy(out, fileExtensions)
+	return out
+}
+
+// spdxTemplateVar matches the SPDX license template variable markup, e.g.
+// <<var;name="copyright";original="Copyright (c) 2020";match=".*">>.
+var spdxTemplateVar = regexp.MustCompile(`(?s)<<.*?>>`)
+
+// stripSPDXTemplateVars removes SPDX template variable markup so that
+// template-backed texts compare the same way as a filled-in license.
+func stripSPDXTemplateVars(s string) string {
+	return spdxTemplateVar.ReplaceAllString(s, "")
+}
+
+// LoadSPDXCorpus loads an SPDX license-list-data checkout. licensesJSONPath
+// is the path to its top-level licenses.json, and templateDir is the
+// directory containing one "<LicenseID>.txt" template per entry (the
+// repository's "text" directory).
+func LoadSPDXCorpus(licensesJSONPath, templateDir string) (*SPDXCorpus, error) {
+	raw, err := os.ReadFile(licensesJSONPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var data spdxLicenseListData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("license: parsing %s: %w", licensesJSONPath, err)
+	}
+
+	corpus := &SPDXCorpus{
+		version:  data.LicenseListVersion,
+		licenses: make(map[string]string, len(data.Licenses)),
+	}
+
+	for _, entry := range data.Licenses {
+		if entry.IsDeprecatedLicenseID {
+			continue
+		}
+
+		text, err := os.ReadFile(filepath.Join(templateDir, entry.LicenseID+".txt"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		corpus.licenses[entry.LicenseID] = stripSPDXTemplateVars(string(text))
+	}
+
+	return corpus, nil
+}
@@ -0,0 +1,269 @@
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// defaultIgnoreDirs are subdirectories that are always skipped by ScanTree,
+// regardless of opts.IgnorePatterns. Dependency directories like vendor and
+// node_modules are deliberately NOT in this set: discovering their vendored
+// licenses is the point of a whole-project audit. Callers who want them
+// skipped can add "vendor" / "node_modules" to opts.IgnorePatterns.
+var defaultIgnoreDirs = map[string]bool{
+	".git": true,
+}
+
+// NewFromDirAll behaves like NewFromDir, except that instead of returning
+// ErrMultipleLicenses when more than one candidate file is present, it
+// loads and returns all of them so the caller can inspect every finding.
+func NewFromDirAll(dir string) ([]*License, error) {
+	matches, err := SearchDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, ErrNoLicenseFile
+	}
+
+	licenses := make([]*License, 0, len(matches))
+	for _, name := range matches {
+		l, err := NewFromFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		licenses = append(licenses, l)
+	}
+	return licenses, nil
+}
+
+// incompatiblePair is an unordered pair of license types that are known to
+// be mutually incompatible when combined in the same distribution.
+type incompatiblePair struct{ a, b string }
+
+// Policy expresses which license types a project is willing to accept.
+type Policy struct {
+	// Allow, if non-empty, is the exclusive set of acceptable license
+	// types; anything else is a violation.
+	Allow []string
+
+	// Deny is a set of license types that are always violations, checked
+	// even when Allow is empty.
+	Deny []string
+
+	// IncompatiblePairs are license types that must not both appear in the
+	// same project, e.g. {"GPL-2.0", "Apache-2.0"}.
+	IncompatiblePairs [][2]string
+}
+
+// Violation describes one way a Report failed to satisfy a Policy.
+type Violation struct {
+	// Type is the license type responsible for the violation.
+	Type string
+
+	// ConflictsWith is set for incompatible-pair violations, naming the
+	// other license type involved.
+	ConflictsWith string
+
+	// Reason is a short human-readable explanation.
+	Reason string
+}
+
+// Report is the result of scanning a directory tree for licenses.
+type Report struct {
+	// Root is the directory that was scanned.
+	Root string
+
+	// Primary is the license found at the top level of Root, if any.
+	Primary *License
+
+	// Subdirectory maps each subdirectory (relative to Root) containing its
+	// own license file to the license(s) found there, e.g. vendored
+	// dependencies. A subdirectory whose license file cannot be read or
+	// recognized appears in neither Subdirectory nor Unlicensed.
+	Subdirectory map[string][]*License
+
+	// Unlicensed lists subdirectories (relative to Root) that contain
+	// files but no discoverable license of their own.
+	Unlicensed []string
+
+	// Violations lists every way the report fails to satisfy the Policy
+	// passed to ScanTree, empty if no Policy was given or none were found.
+	Violations []Violation
+}
+
+// AllLicenses returns every license type found anywhere in the report,
+// including Primary and every subdirectory finding.
+func (r *Report) AllLicenses() map[string]bool {
+	found := make(map[string]bool)
+	if r.Primary != nil {
+		found[r.Primary.Type] = true
+	}
+	for _, licenses := range r.Subdirectory {
+		for _, l := range licenses {
+			found[l.Type] = true
+		}
+	}
+	return found
+}
+
+// hasRegularFiles reports whether dir directly contains at least one
+// regular file.
+func hasRegularFiles(dir string) bool {
+	fis, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPolicy evaluates p against the license types found in r, returning
+// any Violations.
+func checkPolicy(r *Report, p *Policy) []Violation {
+	if p == nil {
+		return nil
+	}
+	return CheckLicenses(r.AllLicenses(), p)
+}
+
+// CheckLicenses evaluates p against found, the set of license types present
+// in some scope (a Report, a bill of materials, ...), and returns every
+// Violation: denied types, types outside an Allow list, and any pair of
+// found types listed in IncompatiblePairs. It is the shared policy engine
+// behind ScanTree's Report.Violations and the bom package's CheckPolicy.
+func CheckLicenses(found map[string]bool, p *Policy) []Violation {
+	if p == nil {
+		return nil
+	}
+
+	allow := make(map[string]bool, len(p.Allow))
+	for _, t := range p.Allow {
+		allow[t] = true
+	}
+	deny := make(map[string]bool, len(p.Deny))
+	for _, t := range p.Deny {
+		deny[t] = true
+	}
+
+	var violations []Violation
+	for ltype := range found {
+		if deny[ltype] {
+			violations = append(violations, Violation{Type: ltype, Reason: "license type is denied by policy"})
+			continue
+		}
+		if len(allow) > 0 && !allow[ltype] {
+			violations = append(violations, Violation{Type: ltype, Reason: "license type is not in the policy allowlist"})
+		}
+	}
+
+	for _, pair := range p.IncompatiblePairs {
+		if found[pair[0]] && found[pair[1]] {
+			violations = append(violations,
+				Violation{Type: pair[0], ConflictsWith: pair[1], Reason: "incompatible license combination"},
+				Violation{Type: pair[1], ConflictsWith: pair[0], Reason: "incompatible license combination"},
+			)
+		}
+	}
+
+	return violations
+}
+
+// scanTreeOptions is the subset of ScanOptions that ScanTree honors, kept
+// distinct from the SPDX scanner's options since the two scans walk for
+// different things.
+type scanTreeOptions struct {
+	ignorePatterns []string
+	policy         *Policy
+	concurrency    int
+}
+
+// ScanTree recursively walks the directory tree rooted at root and returns
+// a Report describing every license found, using a bounded worker pool to
+// read subdirectories in parallel. If opts.Policy is set, the report's
+// Violations are populated from it.
+func ScanTree(root string, opts *ScanOptions) (*Report, error) {
+	sto := scanTreeOptions{concurrency: defaultSPDXConcurrency}
+	if opts != nil {
+		sto.ignorePatterns = opts.IgnorePatterns
+		sto.policy = opts.Policy
+		if opts.Concurrency > 0 {
+			sto.concurrency = opts.Concurrency
+		}
+	}
+
+	report := &Report{Root: root, Subdirectory: make(map[string][]*License)}
+
+	if primary, err := NewFromDirAll(root); err == nil {
+		report.Primary = primary[0]
+	} else if err != ErrNoLicenseFile {
+		return nil, err
+	}
+
+	var dirs []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if defaultIgnoreDirs[filepath.Base(rel)] || matchesAny(sto.ignorePatterns, rel) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, sto.concurrency)
+	)
+	for _, rel := range dirs {
+		rel := rel
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			full := filepath.Join(root, rel)
+			licenses, err := NewFromDirAll(full)
+			if err == ErrNoLicenseFile {
+				if hasRegularFiles(full) {
+					mu.Lock()
+					report.Unlicensed = append(report.Unlicensed, rel)
+					mu.Unlock()
+				}
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				return
+			}
+			report.Subdirectory[rel] = licenses
+		}()
+	}
+	wg.Wait()
+
+	report.Violations = checkPolicy(report, sto.policy)
+	return report, nil
+}